@@ -0,0 +1,45 @@
+package glogrus
+
+import (
+	"time"
+
+	"github.com/obot-platform/kinm/pkg/db/internal/dedup"
+)
+
+// DedupConfig configures a Logger's Deduper. See Config.Dedup.
+type DedupConfig struct {
+	// Window is how long a repeated log entry is suppressed for after its
+	// first occurrence. If zero, 1 minute is used.
+	Window time.Duration
+
+	// MaxFingerprints bounds how many distinct fingerprints are tracked at
+	// once; the least-recently-seen fingerprint is evicted once exceeded.
+	// If zero, 4096 is used.
+	MaxFingerprints int
+}
+
+// Deduper suppresses repeat logrus entries whose fingerprint — a hash of
+// level, message, redacted SQL, and error string — has already been seen
+// within its configured window. Suppressed entries are counted per
+// fingerprint; once the window rolls over, the next matching entry is logged
+// as a single "suppressed N duplicate log entries" summary instead.
+type Deduper struct {
+	tracker *dedup.Tracker
+}
+
+// NewDeduper returns a Deduper configured by cfg.
+func NewDeduper(cfg DedupConfig) *Deduper {
+	return &Deduper{
+		tracker: dedup.NewTracker(dedup.Config{
+			Window:          cfg.Window,
+			MaxFingerprints: cfg.MaxFingerprints,
+		}),
+	}
+}
+
+// Allow reports whether the entry identified by level, msg, sql, and err
+// should be logged now, and if a suppression window just rolled over, how
+// many matching entries were swallowed since it started.
+func (d *Deduper) Allow(level, msg, sql string, err error, now time.Time) (emit bool, suppressed int) {
+	return d.tracker.Allow(dedup.Fingerprint(level, msg, sql, err), now)
+}
@@ -4,11 +4,15 @@ package glogrus
 import (
 	"context"
 	"errors"
-	"regexp"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/obot-platform/kinm/pkg/db/internal/dedup"
+	"github.com/obot-platform/kinm/pkg/db/internal/otelquery"
+	"github.com/obot-platform/kinm/pkg/db/internal/sqlredact"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	glogger "gorm.io/gorm/logger"
 	gutils "gorm.io/gorm/utils"
@@ -30,6 +34,22 @@ type Config struct {
 	//
 	// `gorm.ErrRecordNotFound` logging is disabled IFF IgnoreRecordNotFoundError is true.
 	LogSQL bool
+
+	// Tracer, if set, causes Logger.Trace to record a "gorm.query" event on the span
+	// active in the query's context, in addition to logging.
+	Tracer trace.Tracer
+
+	// Redaction controls how SQL logged by Logger.Trace is sanitized. If nil,
+	// LegacyRedactor is used, which masks every single-quoted string literal
+	// regardless of column or type. Set this to DefaultRedactor for type-aware,
+	// column-allowlisted redaction instead.
+	Redaction Redactor
+
+	// Dedup, if set, suppresses repeat log entries produced by Logger.Trace
+	// whose level, message, redacted SQL, and error all match one seen within
+	// the configured window. Opt in when a hot loop of failing queries would
+	// otherwise drown out other signals.
+	Dedup *DedupConfig
 }
 
 // New returns a new *Logger configured with the given config.
@@ -39,6 +59,11 @@ func New(cfg Config) *Logger {
 		slowThreshold:             cfg.SlowThreshold,
 		ignoreRecordNotFoundError: cfg.IgnoreRecordNotFoundError,
 		logSQL:                    cfg.LogSQL,
+		tracer:                    cfg.Tracer,
+		redactor:                  cfg.Redaction,
+	}
+	if cfg.Dedup != nil {
+		l.dedup = NewDeduper(*cfg.Dedup)
 	}
 	l.complete()
 
@@ -53,6 +78,9 @@ type Logger struct {
 	slowThreshold             time.Duration
 	ignoreRecordNotFoundError bool
 	logSQL                    bool
+	tracer                    trace.Tracer
+	redactor                  Redactor
+	dedup                     *Deduper
 }
 
 func (l *Logger) LogMode(glogger.LogLevel) glogger.Interface {
@@ -79,30 +107,51 @@ func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (string,
 	l.complete()
 	elapsed := time.Since(begin)
 	sql, affected := fc()
+	caller := gutils.FileWithLineNum()
+
+	if l.tracer != nil {
+		otelquery.RecordEvent(ctx, sql, affected, elapsed, caller, err, l.ignoreRecordNotFoundError, l.redactor.Redact)
+	}
 
 	log := l.logger.WithContext(ctx).WithFields(logrus.Fields{
 		"elapsed":  elapsed,
 		"affected": affected,
-		"caller":   gutils.FileWithLineNum(),
+		"caller":   caller,
 	})
 
+	redacted := l.redactor.Redact(sql)
 	if l.logSQL {
 		// Add the SQL query to all log levels if the logger is set to Trace.
 		// Redact sensitive parameters while preserving SQL structure for debugging.
-		log = log.WithField("sql", redactSQLParams(sql))
+		log = log.WithField("sql", redacted)
 	}
 
-	if err != nil && !(l.ignoreRecordNotFoundError && errors.Is(err, gorm.ErrRecordNotFound)) {
-		log.WithError(err).Error("sql query error")
-		return
+	ignoreErr := l.ignoreRecordNotFoundError && errors.Is(err, gorm.ErrRecordNotFound)
+
+	var level logrus.Level
+	var msg string
+	switch {
+	case err != nil && !ignoreErr:
+		level, msg = logrus.ErrorLevel, "sql query error"
+		log = log.WithError(err)
+	case l.slowThreshold != 0 && elapsed > l.slowThreshold:
+		level, msg = logrus.InfoLevel, "sql query slow"
+	default:
+		level, msg = logrus.TraceLevel, "sql query executed"
 	}
 
-	if l.slowThreshold != 0 && elapsed > l.slowThreshold {
-		log.Info("sql query slow")
-		return
+	if l.dedup != nil {
+		emit, suppressed := l.dedup.Allow(level.String(), msg, redacted, err, time.Now())
+		if !emit {
+			return
+		}
+		if suppressed > 0 {
+			log.WithField("suppressed", suppressed).Log(level, fmt.Sprintf("suppressed %d duplicate log entries", suppressed))
+			return
+		}
 	}
 
-	log.Trace("sql query executed")
+	log.Log(level, msg)
 }
 
 // complete ensures that the Logger is fully initialized.
@@ -115,18 +164,18 @@ func (l *Logger) complete() {
 		if l.slowThreshold == 0 {
 			l.slowThreshold = 500 * time.Millisecond
 		}
+		if l.redactor == nil {
+			l.redactor = LegacyRedactor{}
+		}
 	})
 }
 
-var (
-	// sqlStringLiteralRegex matches single-quoted string literals in SQL queries.
-	// This includes escaped quotes ('') within strings.
-	sqlStringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
-)
-
 // redactSQLParams redacts sensitive parameter values from SQL queries while preserving structure.
 // It replaces all single-quoted string literals with '[REDACTED]' to prevent sensitive data
 // exposure in logs while keeping the SQL structure visible for debugging.
+//
+// This delegates to the shared implementation in pkg/db/internal/sqlredact so that
+// pkg/db/gslog redacts queries identically.
 func redactSQLParams(sql string) string {
-	return sqlStringLiteralRegex.ReplaceAllString(sql, "'[REDACTED]'")
+	return sqlredact.Redact(sql)
 }
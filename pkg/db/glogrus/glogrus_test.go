@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -331,3 +332,119 @@ func TestNew_CustomValues(t *testing.T) {
 	assert.True(t, l.ignoreRecordNotFoundError)
 	assert.True(t, l.logSQL)
 }
+
+func TestNew_DefaultRedactorIsLegacy(t *testing.T) {
+	l := New(Config{})
+	assert.Equal(t, LegacyRedactor{}, l.redactor)
+}
+
+func TestDefaultRedactor_Redact(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RedactionPolicy
+		input    string
+		expected string
+	}{
+		{
+			name:     "string literal is redacted with a type tag",
+			input:    "SELECT * FROM users WHERE name = 'John Doe'",
+			expected: "SELECT * FROM users WHERE name = ?:string",
+		},
+		{
+			name:     "numeric literal is redacted with a type tag",
+			input:    "SELECT * FROM users WHERE age = 42",
+			expected: "SELECT * FROM users WHERE age = ?:int",
+		},
+		{
+			name:     "bare uuid literal is redacted with a type tag",
+			input:    "SELECT * FROM sessions WHERE token = 6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			expected: "SELECT * FROM sessions WHERE token = ?:uuid",
+		},
+		{
+			name:     "bare uuid literal starting with a hex letter is redacted with a type tag",
+			input:    "SELECT * FROM sessions WHERE token = deadbeef-1234-5678-9abc-def012345678",
+			expected: "SELECT * FROM sessions WHERE token = ?:uuid",
+		},
+		{
+			name:     "allowed column is preserved",
+			input:    "UPDATE users SET status = 'active' WHERE id = '42'",
+			expected: "UPDATE users SET status = 'active' WHERE id = '42'",
+		},
+		{
+			name:     "custom allowlist is respected",
+			policy:   RedactionPolicy{AllowedColumns: []string{"role"}},
+			input:    "UPDATE users SET role = 'admin', name = 'John' WHERE id = 1",
+			expected: "UPDATE users SET role = 'admin', name = ?:string WHERE id = ?:int",
+		},
+		{
+			name:     "dollar-quoted strings are treated as one literal",
+			input:    "SELECT $$it's fine$$ FROM dual",
+			expected: "SELECT ?:string FROM dual",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := DefaultRedactor{Policy: tt.policy}
+			assert.Equal(t, tt.expected, r.Redact(tt.input))
+		})
+	}
+}
+
+func TestLegacyRedactor_MatchesOriginalBehavior(t *testing.T) {
+	r := LegacyRedactor{}
+	assert.Equal(t, "SELECT * FROM users WHERE name = '[REDACTED]'", r.Redact("SELECT * FROM users WHERE name = 'John Doe'"))
+}
+
+func TestRedactSQLArgs(t *testing.T) {
+	args := redactSQLArgs([]any{"secret", 42, 3.14, nil}, RedactionPolicy{})
+	assert.Equal(t, []any{"?:string", "?:int", "?:float", nil}, args)
+}
+
+func TestLoggerTrace_DedupSuppressesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	l := New(Config{
+		Logger: logger,
+		LogSQL: true,
+		Dedup:  &DedupConfig{Window: time.Hour},
+	})
+
+	testErr := errors.New("database error")
+	trace := func() (string, int64) { return "SELECT * FROM users WHERE id = 'x'", 0 }
+
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+
+	lines := strings.Count(buf.String(), "\n")
+	assert.Equal(t, 1, lines, "repeats within the window should be suppressed")
+	assert.Contains(t, buf.String(), "sql query error")
+}
+
+func TestLoggerTrace_UsesConfiguredRedactor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	l := New(Config{
+		Logger:    logger,
+		LogSQL:    true,
+		Redaction: DefaultRedactor{},
+	})
+
+	l.Trace(context.Background(), time.Now(), func() (string, int64) {
+		return "SELECT * FROM users WHERE name = 'secret123'", 1
+	}, nil)
+
+	output := buf.String()
+	assert.Contains(t, output, "?:string")
+	assert.NotContains(t, output, "secret123")
+	assert.NotContains(t, output, "[REDACTED]")
+}
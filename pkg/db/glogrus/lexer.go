@@ -0,0 +1,243 @@
+package glogrus
+
+import "strings"
+
+// tokenKind classifies a sqlToken produced by tokenizeSQL.
+type tokenKind int
+
+const (
+	tokenSpace tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenHexOrUUID
+	tokenOperator
+	tokenComment
+	tokenOther
+)
+
+// sqlToken is a single lexical unit of a SQL statement, along with its
+// original text so the statement can be reassembled losslessly around
+// redacted literals.
+type sqlToken struct {
+	kind tokenKind
+	text string
+}
+
+// tokenizeSQL splits sql into a flat sequence of tokens. It is a small,
+// purpose-built lexer (not a full SQL parser): it recognizes string literals
+// (including '' escapes, E'...' escape strings, and $tag$...$tag$ dollar
+// quoting), line and block comments, numeric and hex/UUID literals, bare
+// identifiers, and treats everything else as single-character tokens, of which
+// only "=" is tagged as an operator. This is enough for DefaultRedactor to find literals and the column
+// names that precede them without false-positiving on digits inside
+// identifiers or quotes inside comments.
+func tokenizeSQL(sql string) []sqlToken {
+	var tokens []sqlToken
+	i := 0
+	n := len(sql)
+
+	for i < n {
+		c := sql[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			start := i
+			for i < n && isSpace(sql[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{tokenSpace, sql[start:i]})
+
+		case c == '-' && i+1 < n && sql[i+1] == '-':
+			start := i
+			for i < n && sql[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, sqlToken{tokenComment, sql[start:i]})
+
+		case c == '/' && i+1 < n && sql[i+1] == '*':
+			start := i
+			i += 2
+			for i+1 < n && !(sql[i] == '*' && sql[i+1] == '/') {
+				i++
+			}
+			i = min(i+2, n)
+			tokens = append(tokens, sqlToken{tokenComment, sql[start:i]})
+
+		case c == '\'':
+			start := i
+			i = scanQuoted(sql, i, '\'')
+			tokens = append(tokens, sqlToken{tokenString, sql[start:i]})
+
+		case (c == 'e' || c == 'E') && i+1 < n && sql[i+1] == '\'':
+			start := i
+			i = scanQuoted(sql, i+1, '\'')
+			tokens = append(tokens, sqlToken{tokenString, sql[start:i]})
+
+		case c == '$' && isDollarQuoteStart(sql, i):
+			start := i
+			tag, end := readDollarTag(sql, i)
+			i = scanDollarQuoted(sql, end, tag)
+			tokens = append(tokens, sqlToken{tokenString, sql[start:i]})
+
+		case isHexDigit(c) && matchesUUID(sql, i):
+			end := uuidEnd(sql, i)
+			tokens = append(tokens, sqlToken{tokenHexOrUUID, sql[i:end]})
+			i = end
+
+		case isDigit(c):
+			start := i
+			i = scanNumberOrHex(sql, i)
+			kind := tokenNumber
+			if looksLikeHexOrUUID(sql[start:i]) {
+				kind = tokenHexOrUUID
+			}
+			tokens = append(tokens, sqlToken{kind, sql[start:i]})
+
+		case isIdentStart(c):
+			start := i
+			for i < n && isIdentPart(sql[i]) {
+				i++
+			}
+			word := sql[start:i]
+			if looksLikeUUID(word) {
+				tokens = append(tokens, sqlToken{tokenHexOrUUID, word})
+			} else {
+				tokens = append(tokens, sqlToken{tokenIdent, word})
+			}
+
+		case c == '=':
+			tokens = append(tokens, sqlToken{tokenOperator, "="})
+			i++
+
+		default:
+			tokens = append(tokens, sqlToken{tokenOther, string(c)})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+func scanQuoted(sql string, start int, quote byte) int {
+	i := start + 1
+	n := len(sql)
+	for i < n {
+		if sql[i] == quote {
+			if i+1 < n && sql[i+1] == quote {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return n
+}
+
+func isDollarQuoteStart(sql string, i int) bool {
+	_, end := readDollarTag(sql, i)
+	return end > i+1
+}
+
+// readDollarTag reads a `$tag$` delimiter starting at sql[i] and returns the
+// tag text (without the surrounding `$`) and the index just past the closing
+// `$`. If sql[i:] is not a valid dollar-quote opener, end == i+1.
+func readDollarTag(sql string, i int) (tag string, end int) {
+	n := len(sql)
+	if i >= n || sql[i] != '$' {
+		return "", i + 1
+	}
+	j := i + 1
+	for j < n && (isIdentPart(sql[j])) {
+		j++
+	}
+	if j >= n || sql[j] != '$' {
+		return "", i + 1
+	}
+	return sql[i+1 : j], j + 1
+}
+
+func scanDollarQuoted(sql string, start int, tag string) int {
+	delim := "$" + tag + "$"
+	idx := strings.Index(sql[start:], delim)
+	if idx < 0 {
+		return len(sql)
+	}
+	return start + idx + len(delim)
+}
+
+func scanNumberOrHex(sql string, start int) int {
+	i := start
+	n := len(sql)
+	for i < n && (isHexDigit(sql[i]) || sql[i] == '.' || sql[i] == 'x' || sql[i] == 'X' || sql[i] == '-') {
+		i++
+	}
+	return i
+}
+
+func looksLikeHexOrUUID(s string) bool {
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") || looksLikeUUID(s)
+}
+
+func looksLikeUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(byte(c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesUUID reports whether sql[i:] begins with a bare UUID literal
+// (8-4-4-4-12 hex groups separated by hyphens). Unlike looksLikeUUID, which
+// only checks a word already scanned as an identifier, this looks ahead from
+// i so UUIDs starting with a hex letter (a-f) are recognized before
+// isIdentStart/isIdentPart — which don't treat '-' as part of an
+// identifier — would otherwise truncate the scan at the first hyphen.
+func matchesUUID(sql string, i int) bool {
+	end := uuidEnd(sql, i)
+	return end != i
+}
+
+// uuidEnd returns the index just past a bare UUID literal starting at sql[i],
+// or i if sql[i:] doesn't begin with one.
+func uuidEnd(sql string, i int) int {
+	n := len(sql)
+	groupLens := [5]int{8, 4, 4, 4, 12}
+	j := i
+	for g, l := range groupLens {
+		if g > 0 {
+			if j >= n || sql[j] != '-' {
+				return i
+			}
+			j++
+		}
+		if j+l > n {
+			return i
+		}
+		for k := 0; k < l; k++ {
+			if !isHexDigit(sql[j+k]) {
+				return i
+			}
+		}
+		j += l
+	}
+	return j
+}
+
+func isSpace(c byte) bool      { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+func isDigit(c byte) bool      { return c >= '0' && c <= '9' }
+func isHexDigit(c byte) bool   { return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') }
+func isIdentStart(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isIdentPart(c byte) bool  { return isIdentStart(c) || isDigit(c) }
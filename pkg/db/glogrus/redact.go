@@ -0,0 +1,122 @@
+package glogrus
+
+import (
+	"strings"
+
+	"github.com/obot-platform/kinm/pkg/db/internal/sqlredact"
+)
+
+// Redactor strips sensitive values out of SQL text and positional argument
+// slices before they reach the log sink.
+type Redactor interface {
+	// Redact returns sql with sensitive literals replaced.
+	Redact(sql string) string
+}
+
+// RedactionPolicy configures which column values DefaultRedactor preserves.
+type RedactionPolicy struct {
+	// AllowedColumns lists column names (case-insensitive) whose values are never
+	// redacted, e.g. because they are identifiers or enums rather than secrets.
+	// If empty, DefaultAllowedColumns is used.
+	AllowedColumns []string
+}
+
+// DefaultAllowedColumns is the column allowlist used by DefaultRedactor when
+// RedactionPolicy.AllowedColumns is empty.
+var DefaultAllowedColumns = []string{"id", "status"}
+
+func (p RedactionPolicy) allowlist() map[string]bool {
+	cols := p.AllowedColumns
+	if len(cols) == 0 {
+		cols = DefaultAllowedColumns
+	}
+	allowed := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		allowed[strings.ToLower(c)] = true
+	}
+	return allowed
+}
+
+// LegacyRedactor reproduces the original glogrus behavior: every single-quoted
+// string literal is replaced with '[REDACTED]', regardless of the column it
+// belongs to or what kind of value it holds. Callers that depend on that exact
+// output (e.g. existing log parsers) can set Config.Redaction to LegacyRedactor{}.
+type LegacyRedactor struct{}
+
+func (LegacyRedactor) Redact(sql string) string {
+	return sqlredact.Redact(sql)
+}
+
+// DefaultRedactor is a type-aware, column-name-aware Redactor. Rather than a
+// single regular expression, it tokenizes the query so that string, numeric,
+// and hex/UUID literals are each recognized and handled on their own terms,
+// and it tolerates escaped strings (E'...'), dollar-quoted strings ($$...$$),
+// and SQL comments. Values assigned to a column named in Policy.AllowedColumns
+// (via "SET col = ..." or "WHERE col = ...") are preserved verbatim; every
+// other literal is replaced with a type-tagged placeholder such as "?:string",
+// "?:int", or "?:uuid".
+type DefaultRedactor struct {
+	Policy RedactionPolicy
+}
+
+func (d DefaultRedactor) Redact(sql string) string {
+	allowed := d.Policy.allowlist()
+
+	var out strings.Builder
+	var lastIdent string
+
+	tokens := tokenizeSQL(sql)
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokenIdent:
+			lastIdent = strings.ToLower(tok.text)
+			out.WriteString(tok.text)
+		case tokenString, tokenNumber, tokenHexOrUUID:
+			if columnAllows(tokens, i, allowed, lastIdent) {
+				out.WriteString(tok.text)
+			} else {
+				out.WriteString(redactedPlaceholder(tok.kind))
+			}
+		default:
+			out.WriteString(tok.text)
+		}
+	}
+
+	return out.String()
+}
+
+// columnAllows reports whether the literal at tokens[i] is assigned to a
+// column on the allowlist, i.e. the nearest preceding identifier followed by
+// "=" matches an allowed column name.
+func columnAllows(tokens []sqlToken, i int, allowed map[string]bool, lastIdent string) bool {
+	// Walk backwards over whitespace to confirm this literal directly follows
+	// "="; lastIdent is the nearest identifier seen before it.
+	j := i - 1
+	for j >= 0 && tokens[j].kind == tokenSpace {
+		j--
+	}
+	if j < 0 || tokens[j].kind != tokenOperator || tokens[j].text != "=" {
+		return false
+	}
+	return allowed[lastIdent]
+}
+
+func redactedPlaceholder(kind tokenKind) string {
+	switch kind {
+	case tokenNumber:
+		return "?:int"
+	case tokenHexOrUUID:
+		return "?:uuid"
+	default:
+		return "?:string"
+	}
+}
+
+// redactSQLArgs redacts a slice of positional arguments (e.g. gorm's Vars).
+// The policy is not consulted: positional args carry no column name of their
+// own, so every value is replaced with a type-tagged placeholder. It delegates to sqlredact.RedactArgs,
+// which otelgorm also uses to redact tx.Statement.Vars before attaching them
+// to a span.
+func redactSQLArgs(args []any, _ RedactionPolicy) []any {
+	return sqlredact.RedactArgs(args)
+}
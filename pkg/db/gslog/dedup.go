@@ -0,0 +1,98 @@
+package gslog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/obot-platform/kinm/pkg/db/internal/dedup"
+)
+
+// DedupConfig configures a Logger's Deduper. See Config.Dedup.
+type DedupConfig struct {
+	// Window is how long a repeated log entry is suppressed for after its
+	// first occurrence. If zero, 1 minute is used.
+	Window time.Duration
+
+	// MaxFingerprints bounds how many distinct fingerprints are tracked at
+	// once; the least-recently-seen fingerprint is evicted once exceeded.
+	// If zero, 4096 is used.
+	MaxFingerprints int
+}
+
+// Deduper suppresses repeat Logger.Trace entries whose fingerprint — a hash
+// of level, message, redacted SQL, and error string — has already been seen
+// within its configured window. Suppressed entries are counted per
+// fingerprint; once the window rolls over, the next matching entry is logged
+// as a single "suppressed N duplicate log entries" summary instead.
+type Deduper struct {
+	tracker *dedup.Tracker
+}
+
+// NewDeduper returns a Deduper configured by cfg.
+func NewDeduper(cfg DedupConfig) *Deduper {
+	return &Deduper{
+		tracker: dedup.NewTracker(dedup.Config{
+			Window:          cfg.Window,
+			MaxFingerprints: cfg.MaxFingerprints,
+		}),
+	}
+}
+
+// Allow reports whether the entry identified by level, msg, sql, and err
+// should be logged now, and if a suppression window just rolled over, how
+// many matching entries were swallowed since it started.
+func (d *Deduper) Allow(level, msg, sql string, err error, now time.Time) (emit bool, suppressed int) {
+	return d.tracker.Allow(dedup.Fingerprint(level, msg, sql, err), now)
+}
+
+// DedupHandler wraps a slog.Handler, suppressing Handle calls whose level,
+// message, and attributes match one already seen within its configured
+// window. Unlike Deduper (which is wired into Logger.Trace to dedup SQL log
+// lines specifically), DedupHandler can wrap any slog.Handler.
+type DedupHandler struct {
+	slog.Handler
+	tracker *dedup.Tracker
+}
+
+// NewDedupHandler returns a DedupHandler wrapping next.
+func NewDedupHandler(next slog.Handler, cfg DedupConfig) *DedupHandler {
+	return &DedupHandler{
+		Handler: next,
+		tracker: dedup.NewTracker(dedup.Config{
+			Window:          cfg.Window,
+			MaxFingerprints: cfg.MaxFingerprints,
+		}),
+	}
+}
+
+func (h *DedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	var attrs string
+	record.Attrs(func(a slog.Attr) bool {
+		attrs += a.String() + ";"
+		return true
+	})
+
+	fp := dedup.Fingerprint(record.Level.String(), record.Message, attrs, nil)
+	emit, suppressed := h.tracker.Allow(fp, record.Time)
+	if !emit {
+		return nil
+	}
+
+	if suppressed > 0 {
+		summary := record.Clone()
+		summary.Message = "suppressed duplicate log entries"
+		summary.AddAttrs(slog.Int("suppressed", suppressed))
+		return h.Handler.Handle(ctx, summary)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &DedupHandler{Handler: h.Handler.WithAttrs(attrs), tracker: h.tracker}
+}
+
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+	return &DedupHandler{Handler: h.Handler.WithGroup(name), tracker: h.tracker}
+}
@@ -0,0 +1,183 @@
+// Package gslog provides a gorm logger that wraps a *slog.Logger.
+package gslog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/obot-platform/kinm/pkg/db/internal/otelquery"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	glogger "gorm.io/gorm/logger"
+	gutils "gorm.io/gorm/utils"
+)
+
+// Config is used to configure a gorm Logger that wraps a *slog.Logger.
+type Config struct {
+	// Logger is the slog logger to use. If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	// SlowThreshold is the threshold for logging slow queries. If zero, 500ms is used.
+	SlowThreshold time.Duration
+
+	// IgnoreRecordNotFoundError determines if `gorm.ErrRecordNotFound` errors are logged.
+	// `gorm.ErrRecordNotFound` logging is disabled IFF IgnoreRecordNotFoundError is true.
+	IgnoreRecordNotFoundError bool
+
+	// LogSQL determines if SQL queries are included in the log output produced by calls to Logger.Trace.
+	LogSQL bool
+
+	// Tracer, if set, causes Logger.Trace to record a "gorm.query" event on the span
+	// active in the query's context, in addition to logging.
+	Tracer trace.Tracer
+
+	// Dedup, if set, suppresses repeat log entries produced by Logger.Trace
+	// whose level, message, redacted SQL, and error all match one seen within
+	// the configured window. Opt in when a hot loop of failing queries would
+	// otherwise drown out other signals.
+	Dedup *DedupConfig
+
+	// Redaction controls how SQL is redacted before it's attached to a log
+	// line or span event. If nil, legacyRedactor is used, which blanket-
+	// redacts every string literal via sqlredact.Redact. Set this to a
+	// glogrus.DefaultRedactor for type-aware, column-allowlisted redaction
+	// instead.
+	Redaction Redactor
+}
+
+// New returns a new *Logger configured with the given config.
+func New(cfg Config) *Logger {
+	l := &Logger{
+		logger:                    cfg.Logger,
+		slowThreshold:             cfg.SlowThreshold,
+		ignoreRecordNotFoundError: cfg.IgnoreRecordNotFoundError,
+		logSQL:                    cfg.LogSQL,
+		tracer:                    cfg.Tracer,
+		redactor:                  cfg.Redaction,
+	}
+	if cfg.Dedup != nil {
+		l.dedup = NewDeduper(*cfg.Dedup)
+	}
+	l.complete()
+
+	return l
+}
+
+// Logger is a gorm logger that wraps a *slog.Logger.
+// The zero value of Logger is valid and writes to slog.Default() with default settings.
+type Logger struct {
+	logger                    *slog.Logger
+	once                      sync.Once
+	slowThreshold             time.Duration
+	ignoreRecordNotFoundError bool
+	logSQL                    bool
+	tracer                    trace.Tracer
+	dedup                     *Deduper
+	redactor                  Redactor
+}
+
+func (l *Logger) LogMode(glogger.LogLevel) glogger.Interface {
+	l.complete()
+	return l
+}
+
+func (l *Logger) Info(ctx context.Context, s string, args ...any) {
+	l.complete()
+	l.logger.InfoContext(ctx, fmt.Sprintf(s, args...))
+}
+
+func (l *Logger) Warn(ctx context.Context, s string, args ...any) {
+	l.complete()
+	l.logger.WarnContext(ctx, fmt.Sprintf(s, args...))
+}
+
+func (l *Logger) Error(ctx context.Context, s string, args ...any) {
+	l.complete()
+	l.logger.ErrorContext(ctx, fmt.Sprintf(s, args...))
+}
+
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.complete()
+
+	ignore := err != nil && l.ignoreRecordNotFoundError && errors.Is(err, gorm.ErrRecordNotFound)
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold != 0 && elapsed > l.slowThreshold
+
+	level := slog.LevelDebug
+	msg := "sql query executed"
+	switch {
+	case err != nil && !ignore:
+		level = slog.LevelError
+		msg = "sql query error"
+	case slow:
+		level = slog.LevelInfo
+		msg = "sql query slow"
+	}
+
+	logEnabled := !ignore && l.logger.Handler().Enabled(ctx, level)
+	if !logEnabled && l.tracer == nil {
+		return
+	}
+
+	sql, affected := fc()
+	caller := gutils.FileWithLineNum()
+	redacted := l.redactor.Redact(sql)
+
+	if l.tracer != nil {
+		otelquery.RecordEvent(ctx, sql, affected, elapsed, caller, err, l.ignoreRecordNotFoundError, l.redactor.Redact)
+	}
+
+	if !logEnabled {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, 6)
+	attrs = append(attrs,
+		slog.Duration("elapsed", elapsed),
+		slog.Int64("rows", affected),
+		slog.String("caller", caller),
+	)
+
+	if l.logSQL {
+		// Add the SQL query to the log output. Redact sensitive parameters while
+		// preserving SQL structure for debugging.
+		attrs = append(attrs, slog.String("sql", redacted))
+	}
+
+	if err != nil && !ignore {
+		attrs = append(attrs, slog.String("err", err.Error()))
+	}
+
+	if l.dedup != nil {
+		emit, suppressed := l.dedup.Allow(level.String(), msg, redacted, err, time.Now())
+		if !emit {
+			return
+		}
+		if suppressed > 0 {
+			l.logger.LogAttrs(ctx, level, fmt.Sprintf("suppressed %d duplicate log entries", suppressed), append(attrs, slog.Int("suppressed", suppressed))...)
+			return
+		}
+	}
+
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// complete ensures that the Logger is fully initialized.
+// It's idempotent and should be called at the beginning of every method exported by Logger.
+func (l *Logger) complete() {
+	l.once.Do(func() {
+		if l.logger == nil {
+			l.logger = slog.Default()
+		}
+		if l.slowThreshold == 0 {
+			l.slowThreshold = 500 * time.Millisecond
+		}
+		if l.redactor == nil {
+			l.redactor = legacyRedactor{}
+		}
+	})
+}
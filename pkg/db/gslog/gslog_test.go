@@ -0,0 +1,261 @@
+package gslog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+)
+
+func newTestLogger(buf *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level}))
+}
+
+func TestLoggerTrace_WithLogSQL(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:        newTestLogger(&buf, slog.LevelDebug),
+		SlowThreshold: 100 * time.Millisecond,
+		LogSQL:        true,
+	})
+
+	sql := "SELECT * FROM users WHERE name = 'secret123'"
+	l.Trace(context.Background(), time.Now(), func() (string, int64) { return sql, 1 }, nil)
+
+	output := buf.String()
+	assert.Contains(t, output, `"sql":`)
+	assert.Contains(t, output, "[REDACTED]")
+	assert.NotContains(t, output, "secret123")
+}
+
+type upperRedactor struct{}
+
+func (upperRedactor) Redact(sql string) string { return strings.ToUpper(sql) }
+
+func TestLoggerTrace_CustomRedactor(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:    newTestLogger(&buf, slog.LevelDebug),
+		LogSQL:    true,
+		Redaction: upperRedactor{},
+	})
+
+	l.Trace(context.Background(), time.Now(), func() (string, int64) { return "select 1", 1 }, nil)
+
+	output := buf.String()
+	assert.Contains(t, output, "SELECT 1", "custom Redaction should replace the default legacyRedactor")
+}
+
+func TestLoggerTrace_WithoutLogSQL(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:        newTestLogger(&buf, slog.LevelDebug),
+		SlowThreshold: 100 * time.Millisecond,
+		LogSQL:        false,
+	})
+
+	sql := "SELECT * FROM users WHERE name = 'secret123'"
+	l.Trace(context.Background(), time.Now(), func() (string, int64) { return sql, 1 }, nil)
+
+	output := buf.String()
+	assert.NotContains(t, output, `"sql":`)
+	assert.NotContains(t, output, "secret123")
+}
+
+func TestLoggerTrace_WithError(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger: newTestLogger(&buf, slog.LevelDebug),
+		LogSQL: true,
+	})
+
+	sql := "SELECT * FROM users WHERE id = 'bad-id'"
+	testErr := errors.New("database error")
+	l.Trace(context.Background(), time.Now(), func() (string, int64) { return sql, 0 }, testErr)
+
+	output := buf.String()
+	assert.Contains(t, output, "sql query error")
+	assert.Contains(t, output, "[REDACTED]")
+	assert.NotContains(t, output, "bad-id")
+	assert.Contains(t, output, "database error")
+}
+
+func TestLoggerTrace_IgnoreRecordNotFound(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:                    newTestLogger(&buf, slog.LevelDebug),
+		IgnoreRecordNotFoundError: true,
+		LogSQL:                    true,
+	})
+
+	l.Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 1", 0 }, gorm.ErrRecordNotFound)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestLoggerTrace_SlowQuery(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:        newTestLogger(&buf, slog.LevelInfo),
+		SlowThreshold: 10 * time.Millisecond,
+		LogSQL:        true,
+	})
+
+	begin := time.Now().Add(-50 * time.Millisecond)
+	sql := "SELECT * FROM users WHERE email = 'slow@example.com'"
+	l.Trace(context.Background(), begin, func() (string, int64) { return sql, 100 }, nil)
+
+	output := buf.String()
+	assert.Contains(t, output, "sql query slow")
+	assert.Contains(t, output, "[REDACTED]")
+	assert.NotContains(t, output, "slow@example.com")
+}
+
+func TestLoggerTrace_DisabledLevelNoOps(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	l := New(Config{
+		Logger: newTestLogger(&buf, slog.LevelError),
+		LogSQL: true,
+	})
+
+	l.Trace(context.Background(), time.Now(), func() (string, int64) {
+		calls++
+		return "SELECT 1", 1
+	}, nil)
+
+	assert.Empty(t, buf.String())
+	assert.Zero(t, calls, "fc should not be called when the level is disabled")
+}
+
+func TestLoggerTrace_DedupSuppressesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger: newTestLogger(&buf, slog.LevelDebug),
+		LogSQL: true,
+		Dedup:  &DedupConfig{Window: time.Hour},
+	})
+
+	testErr := errors.New("database error")
+	trace := func() (string, int64) { return "SELECT * FROM users WHERE id = 'x'", 0 }
+
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+
+	lines := strings.Count(buf.String(), "\n")
+	assert.Equal(t, 1, lines, "repeats within the window should be suppressed")
+}
+
+func TestLoggerTrace_DedupRolloverSummaryCarriesOriginalFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger: newTestLogger(&buf, slog.LevelDebug),
+		LogSQL: true,
+		Dedup:  &DedupConfig{Window: time.Millisecond},
+	})
+
+	testErr := errors.New("database error")
+	trace := func() (string, int64) { return "SELECT * FROM users WHERE id = 'x'", 0 }
+
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+	time.Sleep(2 * time.Millisecond)
+	l.Trace(context.Background(), time.Now(), trace, testErr)
+
+	output := buf.String()
+	assert.Contains(t, output, "suppressed 1 duplicate log entries")
+	assert.Contains(t, output, `"elapsed":`)
+	assert.Contains(t, output, `"rows":0`)
+	assert.Contains(t, output, `"caller":`)
+	assert.Contains(t, output, "[REDACTED]")
+	assert.Contains(t, output, "database error")
+}
+
+func TestLoggerTrace_IgnoredRecordNotFoundStillRecordsSpanEvent(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := tp.Tracer("test")
+
+	var buf bytes.Buffer
+	l := New(Config{
+		Logger:                    newTestLogger(&buf, slog.LevelDebug),
+		IgnoreRecordNotFoundError: true,
+		LogSQL:                    true,
+		Tracer:                    tracer,
+	})
+
+	ctx, span := tracer.Start(context.Background(), "parent")
+	l.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 0 }, gorm.ErrRecordNotFound)
+	span.End()
+
+	assert.Empty(t, buf.String(), "logging is still skipped for an ignored error")
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 1, "the gorm.query span event should still be recorded even when the error is ignored")
+	assert.Equal(t, "gorm.query", events[0].Name)
+}
+
+func TestLoggerLogMode(t *testing.T) {
+	l := &Logger{}
+	result := l.LogMode(0)
+	assert.NotNil(t, result)
+	assert.Equal(t, l, result)
+}
+
+func TestLoggerZeroValue(t *testing.T) {
+	var l Logger
+	require.NotPanics(t, func() {
+		l.Info(context.Background(), "test")
+	})
+}
+
+func TestNew_DefaultValues(t *testing.T) {
+	l := New(Config{})
+
+	assert.NotNil(t, l)
+	assert.NotNil(t, l.logger)
+	assert.Equal(t, 500*time.Millisecond, l.slowThreshold)
+	assert.False(t, l.ignoreRecordNotFoundError)
+	assert.False(t, l.logSQL)
+}
+
+func TestDedupHandler_SuppressesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.NewJSONHandler(&buf, nil)
+	h := NewDedupHandler(base, DedupConfig{Window: time.Hour})
+	logger := slog.New(h)
+
+	logger.Info("hello", "k", "v")
+	logger.Info("hello", "k", "v")
+	logger.Info("hello", "k", "v")
+
+	lines := strings.Count(buf.String(), "\n")
+	assert.Equal(t, 1, lines)
+}
+
+func TestNew_CustomValues(t *testing.T) {
+	logger := slog.Default()
+	l := New(Config{
+		Logger:                    logger,
+		SlowThreshold:             200 * time.Millisecond,
+		IgnoreRecordNotFoundError: true,
+		LogSQL:                    true,
+	})
+
+	assert.Equal(t, logger, l.logger)
+	assert.Equal(t, 200*time.Millisecond, l.slowThreshold)
+	assert.True(t, l.ignoreRecordNotFoundError)
+	assert.True(t, l.logSQL)
+}
@@ -0,0 +1,23 @@
+package gslog
+
+import "github.com/obot-platform/kinm/pkg/db/internal/sqlredact"
+
+// Redactor strips sensitive values out of SQL text before it's attached to a
+// log line or span event. Its shape matches glogrus.Redactor, so a
+// glogrus.DefaultRedactor or glogrus.LegacyRedactor can be passed directly as
+// Config.Redaction to share type-aware, column-allowlisted redaction with the
+// logrus-based logger instead of gslog's own blanket regex default.
+type Redactor interface {
+	// Redact returns sql with sensitive literals replaced.
+	Redact(sql string) string
+}
+
+// legacyRedactor is the zero-value default: every single-quoted string
+// literal is replaced with '[REDACTED]', regardless of column or value type.
+// This preserves gslog's original behavior for callers that don't set
+// Config.Redaction.
+type legacyRedactor struct{}
+
+func (legacyRedactor) Redact(sql string) string {
+	return sqlredact.Redact(sql)
+}
@@ -0,0 +1,121 @@
+// Package dedup implements the fingerprint-and-window bookkeeping shared by
+// the deduping loggers in pkg/db/glogrus and pkg/db/gslog.
+package dedup
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// DefaultWindow is the suppression window used when Config.Window is zero.
+const DefaultWindow = time.Minute
+
+// DefaultMaxFingerprints is the LRU size used when Config.MaxFingerprints is zero.
+const DefaultMaxFingerprints = 4096
+
+// Config configures a Tracker.
+type Config struct {
+	// Window is how long a fingerprint is suppressed for after its first
+	// occurrence. If zero, DefaultWindow is used.
+	Window time.Duration
+
+	// MaxFingerprints bounds how many distinct fingerprints are tracked at
+	// once; the least-recently-seen fingerprint is evicted once exceeded.
+	// If zero, DefaultMaxFingerprints is used.
+	MaxFingerprints int
+}
+
+// Tracker suppresses repeat entries whose fingerprint has already been seen
+// within Window, and reports how many were suppressed once the window for
+// that fingerprint rolls over. It is safe for concurrent use.
+type Tracker struct {
+	window          time.Duration
+	maxFingerprints int
+
+	mu    sync.Mutex
+	byFP  map[string]*list.Element
+	order *list.List
+}
+
+type state struct {
+	fingerprint string
+	firstSeen   time.Time
+	suppressed  int
+}
+
+// NewTracker returns a Tracker configured by cfg.
+func NewTracker(cfg Config) *Tracker {
+	window := cfg.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+	maxFP := cfg.MaxFingerprints
+	if maxFP == 0 {
+		maxFP = DefaultMaxFingerprints
+	}
+	return &Tracker{
+		window:          window,
+		maxFingerprints: maxFP,
+		byFP:            map[string]*list.Element{},
+		order:           list.New(),
+	}
+}
+
+// Allow reports whether the entry identified by fingerprint should be emitted
+// now. When it returns false, the entry was suppressed. When it returns true
+// with suppressed > 0, the suppression window for this fingerprint just
+// rolled over and suppressed counts how many entries were swallowed since it
+// started; the caller should log a single summary line instead of (or ahead
+// of) the entry itself.
+func (t *Tracker) Allow(fingerprint string, now time.Time) (emit bool, suppressed int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, ok := t.byFP[fingerprint]; ok {
+		t.order.MoveToFront(elem)
+		st := elem.Value.(*state)
+		if now.Sub(st.firstSeen) < t.window {
+			st.suppressed++
+			return false, 0
+		}
+		suppressed = st.suppressed
+		st.firstSeen = now
+		st.suppressed = 0
+		return true, suppressed
+	}
+
+	elem := t.order.PushFront(&state{fingerprint: fingerprint, firstSeen: now})
+	t.byFP[fingerprint] = elem
+	t.evict()
+	return true, 0
+}
+
+func (t *Tracker) evict() {
+	for len(t.byFP) > t.maxFingerprints {
+		back := t.order.Back()
+		if back == nil {
+			return
+		}
+		t.order.Remove(back)
+		delete(t.byFP, back.Value.(*state).fingerprint)
+	}
+}
+
+// Fingerprint hashes the parts of a log entry that identify it as a repeat of
+// another: level, message, redacted SQL, and error string.
+func Fingerprint(level, msg, sql string, err error) string {
+	h := sha256.New()
+	h.Write([]byte(level))
+	h.Write([]byte{0})
+	h.Write([]byte(msg))
+	h.Write([]byte{0})
+	h.Write([]byte(sql))
+	h.Write([]byte{0})
+	if err != nil {
+		h.Write([]byte(err.Error()))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_SuppressesWithinWindow(t *testing.T) {
+	tr := NewTracker(Config{Window: time.Minute})
+	now := time.Now()
+
+	emit, suppressed := tr.Allow("fp1", now)
+	assert.True(t, emit)
+	assert.Zero(t, suppressed)
+
+	emit, _ = tr.Allow("fp1", now.Add(time.Second))
+	assert.False(t, emit)
+
+	emit, _ = tr.Allow("fp1", now.Add(2*time.Second))
+	assert.False(t, emit)
+}
+
+func TestTracker_EmitsSummaryOnRollover(t *testing.T) {
+	tr := NewTracker(Config{Window: time.Minute})
+	now := time.Now()
+
+	_, _ = tr.Allow("fp1", now)
+	_, _ = tr.Allow("fp1", now.Add(time.Second))
+	_, _ = tr.Allow("fp1", now.Add(2*time.Second))
+
+	emit, suppressed := tr.Allow("fp1", now.Add(2*time.Minute))
+	assert.True(t, emit)
+	assert.Equal(t, 2, suppressed)
+}
+
+func TestTracker_EvictsLeastRecentlySeen(t *testing.T) {
+	tr := NewTracker(Config{Window: time.Minute, MaxFingerprints: 2})
+	now := time.Now()
+
+	tr.Allow("fp1", now)
+	tr.Allow("fp2", now)
+	tr.Allow("fp3", now) // evicts fp1
+
+	emit, suppressed := tr.Allow("fp1", now)
+	assert.True(t, emit, "fp1 should have been evicted and treated as new")
+	assert.Zero(t, suppressed)
+}
+
+func TestFingerprint_StableAndDistinct(t *testing.T) {
+	a := Fingerprint("error", "sql query error", "SELECT 1", nil)
+	b := Fingerprint("error", "sql query error", "SELECT 1", nil)
+	c := Fingerprint("error", "sql query error", "SELECT 2", nil)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
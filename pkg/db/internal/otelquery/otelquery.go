@@ -0,0 +1,39 @@
+// Package otelquery records gorm query outcomes onto the active OpenTelemetry
+// span. It is shared by pkg/db/glogrus and pkg/db/gslog so both loggers emit
+// identical span events when their Config.Tracer is set.
+package otelquery
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// RecordEvent records a "gorm.query" event on the span active in ctx, if any,
+// and marks the span as errored unless err is a `gorm.ErrRecordNotFound` that
+// the caller has asked to ignore. redact is applied to sql before it is
+// attached to the span, so callers can plug in whichever Redactor their
+// Config uses.
+func RecordEvent(ctx context.Context, sql string, affected int64, elapsed time.Duration, caller string, err error, ignoreRecordNotFound bool, redact func(string) string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent("gorm.query", trace.WithAttributes(
+		attribute.String("db.statement", redact(sql)),
+		attribute.Int64("db.rows_affected", affected),
+		attribute.Int64("db.duration_ms", elapsed.Milliseconds()),
+		attribute.String("code.function", caller),
+	))
+
+	if err != nil && !(ignoreRecordNotFound && errors.Is(err, gorm.ErrRecordNotFound)) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
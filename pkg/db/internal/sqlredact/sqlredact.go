@@ -0,0 +1,43 @@
+// Package sqlredact provides SQL string redaction shared by the gorm logger
+// implementations in pkg/db/glogrus and pkg/db/gslog.
+package sqlredact
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// stringLiteralRegex matches single-quoted string literals in SQL queries.
+// This includes escaped quotes ('') within strings.
+var stringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// Redact redacts sensitive parameter values from SQL queries while preserving structure.
+// It replaces all single-quoted string literals with '[REDACTED]' to prevent sensitive data
+// exposure in logs while keeping the SQL structure visible for debugging.
+func Redact(sql string) string {
+	return stringLiteralRegex.ReplaceAllString(sql, "'[REDACTED]'")
+}
+
+// RedactArgs redacts a slice of positional query arguments (e.g. gorm's
+// Statement.Vars), replacing each with a type-tagged placeholder. Unlike
+// Redact, which works on already-interpolated SQL text, this is for callers
+// that see arguments before they're substituted into the query and so have
+// no SQL text to run a regex over.
+func RedactArgs(args []any) []any {
+	redacted := make([]any, len(args))
+	for i, a := range args {
+		switch a.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			redacted[i] = "?:int"
+		case float32, float64:
+			redacted[i] = "?:float"
+		case string:
+			redacted[i] = "?:string"
+		case nil:
+			redacted[i] = nil
+		default:
+			redacted[i] = fmt.Sprintf("?:%T", a)
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,47 @@
+package sqlredact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "single string literal",
+			input:    "SELECT * FROM users WHERE name = 'John Doe'",
+			expected: "SELECT * FROM users WHERE name = '[REDACTED]'",
+		},
+		{
+			name:     "multiple string literals",
+			input:    "INSERT INTO users (name, email) VALUES ('John Doe', 'john@example.com')",
+			expected: "INSERT INTO users (name, email) VALUES ('[REDACTED]', '[REDACTED]')",
+		},
+		{
+			name:     "no string literals",
+			input:    "SELECT * FROM users WHERE id = 123",
+			expected: "SELECT * FROM users WHERE id = 123",
+		},
+		{
+			name:     "string with escaped quotes",
+			input:    "INSERT INTO users (name) VALUES ('O''Brien')",
+			expected: "INSERT INTO users (name) VALUES ('[REDACTED]')",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Redact(tt.input))
+		})
+	}
+}
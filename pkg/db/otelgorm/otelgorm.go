@@ -0,0 +1,134 @@
+// Package otelgorm wires an OpenTelemetry tracer into gorm's callback chain so
+// that individual database operations show up alongside the API-server spans
+// produced by pkg/otel.
+package otelgorm
+
+import (
+	"fmt"
+
+	"github.com/obot-platform/kinm/pkg/db/internal/sqlredact"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey is the gorm instance setting under which the in-flight span is stashed
+// between the callback's "before" and "after" phases.
+const spanKey = "otelgorm:span"
+
+// callbacks names every gorm callback point that Register instruments, paired
+// with the op name used in the resulting span.
+var callbacks = []struct {
+	name string
+	op   string
+}{
+	{"create", "create"},
+	{"query", "query"},
+	{"update", "update"},
+	{"delete", "delete"},
+	{"row", "row"},
+	{"raw", "raw"},
+}
+
+// Register installs a gorm plugin that starts a child span named
+// "db.<op>.<table>" around each create/query/update/delete/row/raw callback,
+// using tracer to create the span.
+func Register(db *gorm.DB, tracer trace.Tracer) error {
+	for _, cb := range callbacks {
+		if err := registerCallback(db, cb.name, cb.op, tracer); err != nil {
+			return fmt.Errorf("otelgorm: registering %s callbacks: %w", cb.name, err)
+		}
+	}
+	return nil
+}
+
+func registerCallback(db *gorm.DB, name, op string, tracer trace.Tracer) error {
+	callback := db.Callback()
+	scope := callbackFor(callback, name)
+	if scope == nil {
+		return fmt.Errorf("no %q callback on this gorm.DB", name)
+	}
+
+	before := func(tx *gorm.DB) {
+		table := tx.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		ctx, span := tracer.Start(tx.Statement.Context, "db."+op+"."+table)
+		if len(tx.Statement.Vars) > 0 {
+			span.SetAttributes(attribute.StringSlice("db.args", redactArgsForSpan(tx.Statement.Vars)))
+		}
+		tx.Statement.Context = ctx
+		tx.InstanceSet(spanKey, span)
+	}
+
+	after := func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span, ok := v.(trace.Span)
+		if !ok {
+			return
+		}
+		// Statement.Vars is filled in while gorm builds the SQL inside its own
+		// callback (e.g. for a query), so db.args is set again here, once the
+		// args are complete, replacing whatever before could see.
+		if len(tx.Statement.Vars) > 0 {
+			span.SetAttributes(attribute.StringSlice("db.args", redactArgsForSpan(tx.Statement.Vars)))
+		}
+		if err := tx.Error; err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	// Anchor to gorm's own registered callback name (e.g. "gorm:create") so the
+	// before/after pair actually brackets the callback gorm runs for this
+	// operation, rather than a name nothing else in the chain uses.
+	anchor := "gorm:" + name
+
+	if err := scope.Before(anchor).Register("otelgorm:"+name+"_before", before); err != nil {
+		return err
+	}
+	return scope.After(anchor).Register("otelgorm:"+name+"_after", after)
+}
+
+// redactArgsForSpan redacts tx.Statement.Vars via sqlredact.RedactArgs and
+// renders each value as a string suitable for an attribute.StringSlice, since
+// span attributes can't hold a []any directly.
+func redactArgsForSpan(vars []any) []string {
+	redacted := sqlredact.RedactArgs(vars)
+	out := make([]string, len(redacted))
+	for i, v := range redacted {
+		if v == nil {
+			out[i] = "<nil>"
+			continue
+		}
+		out[i] = fmt.Sprintf("%v", v)
+	}
+	return out
+}
+
+// callbackFor returns the callback processor for the given gorm callback name
+// (one of create, query, update, delete, row, raw).
+func callbackFor(callback *gorm.Callback, name string) *gorm.CallbackProcessor {
+	switch name {
+	case "create":
+		return callback.Create()
+	case "query":
+		return callback.Query()
+	case "update":
+		return callback.Update()
+	case "delete":
+		return callback.Delete()
+	case "row":
+		return callback.Row()
+	case "raw":
+		return callback.Raw()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package otelgorm
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   int
+	Name string
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return db, mock
+}
+
+// TestRegister_SpanStraddlesRealCallback verifies that the before/after hooks
+// are anchored to gorm's own callback names (see registerCallback), so the
+// span they produce actually wraps the query gorm runs rather than firing at
+// the wrong point in the chain — or not firing at all.
+func TestRegister_SpanStraddlesRealCallback(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "widgets" WHERE name = \$1`).
+		WithArgs("gadget").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "gadget"))
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := tp.Tracer("test")
+
+	require.NoError(t, Register(db, tracer))
+
+	var widgets []widget
+	require.NoError(t, db.Where("name = ?", "gadget").Find(&widgets).Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1, "the query callback should have produced exactly one finished span")
+	assert.Equal(t, "db.query.widgets", spans[0].Name())
+}
+
+// TestRegister_RedactsArgsOnSpan verifies that positional query args are
+// redacted before being attached to the span, rather than leaking the raw
+// bound values.
+func TestRegister_RedactsArgsOnSpan(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "widgets" WHERE name = \$1`).
+		WithArgs("super-secret").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := tp.Tracer("test")
+
+	require.NoError(t, Register(db, tracer))
+
+	var widgets []widget
+	require.NoError(t, db.Where("name = ?", "super-secret").Find(&widgets).Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+
+	attrs := spans[0].Attributes()
+	require.NotEmpty(t, attrs)
+	found := false
+	for _, a := range attrs {
+		if string(a.Key) != "db.args" {
+			continue
+		}
+		found = true
+		for _, v := range a.Value.AsStringSlice() {
+			assert.NotContains(t, v, "super-secret")
+		}
+	}
+	assert.True(t, found, "expected a db.args attribute on the span")
+}
+
+// TestRegister_RecordsErrorOnSpan verifies that a failing query is recorded
+// on the span produced around it.
+func TestRegister_RecordsErrorOnSpan(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "widgets"`).WillReturnError(gorm.ErrInvalidDB)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := tp.Tracer("test")
+
+	require.NoError(t, Register(db, tracer))
+
+	var widgets []widget
+	require.Error(t, db.Find(&widgets).Error)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.NotEqual(t, 0, int(spans[0].Status().Code), "an error on the query should mark the span as errored")
+}
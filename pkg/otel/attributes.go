@@ -6,6 +6,7 @@ import (
 	"github.com/obot-platform/kinm/pkg/types"
 
 	"go.opentelemetry.io/otel/attribute"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/storage"
 )
 
@@ -41,3 +42,81 @@ func ObjectToAttributes(obj types.Object, otherAttributes ...attribute.KeyValue)
 		attribute.String("namespace", obj.GetNamespace()),
 	)
 }
+
+// WatchAttributes mirrors ListOptionsToAttributes for a watch request, additionally
+// tagging the span with watch=true so list and watch calls are easy to tell apart.
+func WatchAttributes(opts storage.ListOptions, otherAttributes ...attribute.KeyValue) []attribute.KeyValue {
+	attrs := ListOptionsToAttributes(opts, otherAttributes...)
+	return append(attrs, attribute.Bool("watch", true))
+}
+
+func CreateOptionsToAttributes(opts *metav1.CreateOptions, otherAttributes ...attribute.KeyValue) []attribute.KeyValue {
+	if opts == nil {
+		opts = &metav1.CreateOptions{}
+	}
+	return append(otherAttributes,
+		attribute.StringSlice("dryRun", opts.DryRun),
+		attribute.String("fieldManager", opts.FieldManager),
+		attribute.String("fieldValidation", opts.FieldValidation),
+	)
+}
+
+func UpdateOptionsToAttributes(opts *metav1.UpdateOptions, otherAttributes ...attribute.KeyValue) []attribute.KeyValue {
+	if opts == nil {
+		opts = &metav1.UpdateOptions{}
+	}
+	return append(otherAttributes,
+		attribute.StringSlice("dryRun", opts.DryRun),
+		attribute.String("fieldManager", opts.FieldManager),
+		attribute.String("fieldValidation", opts.FieldValidation),
+	)
+}
+
+func PatchOptionsToAttributes(opts *metav1.PatchOptions, otherAttributes ...attribute.KeyValue) []attribute.KeyValue {
+	if opts == nil {
+		opts = &metav1.PatchOptions{}
+	}
+	return append(otherAttributes,
+		attribute.StringSlice("dryRun", opts.DryRun),
+		attribute.Bool("force", opts.Force != nil && *opts.Force),
+		attribute.String("fieldManager", opts.FieldManager),
+		attribute.String("fieldValidation", opts.FieldValidation),
+	)
+}
+
+// DeleteOptionsToAttributes captures the fields of a DeleteOptions that affect
+// how a delete is actually carried out, so traces can explain why a delete
+// behaved the way it did (e.g. a precondition mismatch or a background GC).
+func DeleteOptionsToAttributes(opts *metav1.DeleteOptions, otherAttributes ...attribute.KeyValue) []attribute.KeyValue {
+	if opts == nil {
+		opts = &metav1.DeleteOptions{}
+	}
+
+	var gracePeriodSeconds int64 = -1
+	if opts.GracePeriodSeconds != nil {
+		gracePeriodSeconds = *opts.GracePeriodSeconds
+	}
+
+	var propagationPolicy string
+	if opts.PropagationPolicy != nil {
+		propagationPolicy = string(*opts.PropagationPolicy)
+	}
+
+	var preconditionUID string
+	if opts.Preconditions != nil && opts.Preconditions.UID != nil {
+		preconditionUID = string(*opts.Preconditions.UID)
+	}
+
+	var resourceVersion string
+	if opts.Preconditions != nil && opts.Preconditions.ResourceVersion != nil {
+		resourceVersion = *opts.Preconditions.ResourceVersion
+	}
+
+	return append(otherAttributes,
+		attribute.Int64("gracePeriodSeconds", gracePeriodSeconds),
+		attribute.String("propagationPolicy", propagationPolicy),
+		attribute.String("preconditions.uid", preconditionUID),
+		attribute.String("resourceVersion", resourceVersion),
+		attribute.StringSlice("dryRun", opts.DryRun),
+	)
+}
@@ -0,0 +1,82 @@
+package otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestDeleteOptionsToAttributes_NilOptions(t *testing.T) {
+	attrs := attrMap(DeleteOptionsToAttributes(nil))
+
+	assert.Equal(t, int64(-1), attrs["gracePeriodSeconds"].AsInt64(), "nil GracePeriodSeconds should surface as the sentinel -1, not a panic")
+	assert.Equal(t, "", attrs["preconditions.uid"].AsString())
+	assert.Equal(t, "", attrs["resourceVersion"].AsString())
+	assert.Equal(t, "", attrs["propagationPolicy"].AsString())
+}
+
+func TestDeleteOptionsToAttributes_NilPreconditions(t *testing.T) {
+	attrs := attrMap(DeleteOptionsToAttributes(&metav1.DeleteOptions{}))
+
+	assert.Equal(t, "", attrs["preconditions.uid"].AsString(), "nil Preconditions must not panic on opts.Preconditions.UID")
+	assert.Equal(t, "", attrs["resourceVersion"].AsString())
+}
+
+func TestDeleteOptionsToAttributes_PreconditionsAndGracePeriod(t *testing.T) {
+	uid := types.UID("abc-123")
+	rv := "7"
+	var gracePeriod int64 = 30
+	policy := metav1.DeletePropagationForeground
+
+	attrs := attrMap(DeleteOptionsToAttributes(&metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+		PropagationPolicy:  &policy,
+		Preconditions: &metav1.Preconditions{
+			UID:             &uid,
+			ResourceVersion: &rv,
+		},
+		DryRun: []string{"All"},
+	}))
+
+	assert.Equal(t, int64(30), attrs["gracePeriodSeconds"].AsInt64())
+	assert.Equal(t, "Foreground", attrs["propagationPolicy"].AsString())
+	assert.Equal(t, "abc-123", attrs["preconditions.uid"].AsString())
+	assert.Equal(t, "7", attrs["resourceVersion"].AsString())
+	assert.Equal(t, []string{"All"}, attrs["dryRun"].AsStringSlice())
+}
+
+func TestDeleteOptionsToAttributes_PreconditionsWithoutUIDOrResourceVersion(t *testing.T) {
+	attrs := attrMap(DeleteOptionsToAttributes(&metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{},
+	}))
+
+	assert.Equal(t, "", attrs["preconditions.uid"].AsString(), "a non-nil Preconditions with a nil UID must not panic")
+	assert.Equal(t, "", attrs["resourceVersion"].AsString())
+}
+
+func TestWatchAttributes_NoDuplicateSendInitialEvents(t *testing.T) {
+	attrs := WatchAttributes(storage.ListOptions{})
+
+	count := 0
+	for _, kv := range attrs {
+		if kv.Key == "sendInitialEvents" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count, "sendInitialEvents should only be attached once, by the wrapped ListOptionsToAttributes call")
+
+	m := attrMap(attrs)
+	assert.Equal(t, true, m["watch"].AsBool())
+}
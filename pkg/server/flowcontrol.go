@@ -0,0 +1,38 @@
+package server
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilflowcontrol "k8s.io/apiserver/pkg/util/flowcontrol"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// defaultRequestWaitLimit mirrors kube-apiserver's own default for how long a
+// request may sit queued under API Priority and Fairness before it's
+// rejected.
+const defaultRequestWaitLimit = 30 * time.Second
+
+// newFlowControlController builds the utilflowcontrol.Interface that backs
+// genericapiserver.Config.FlowControl. kinm has no etcd-backed
+// PriorityLevelConfiguration/FlowSchema storage of its own, so the controller
+// is handed a fake clientset seeded once with cfg's bootstrap objects —
+// PriorityAndFairness in kinm is static configuration, not a resource callers
+// edit at runtime, which matches how cfg.PriorityLevels()/FlowSchemas() are
+// already assembled from compiled-in defaults plus Register* calls.
+func newFlowControlController(cfg *PriorityAndFairnessConfig, serverConcurrencyLimit int) (utilflowcontrol.Interface, informers.SharedInformerFactory, error) {
+	objects := make([]runtime.Object, 0, len(cfg.PriorityLevels())+len(cfg.FlowSchemas()))
+	for _, pl := range cfg.PriorityLevels() {
+		objects = append(objects, pl)
+	}
+	for _, fs := range cfg.FlowSchemas() {
+		objects = append(objects, fs)
+	}
+
+	clientset := fake.NewSimpleClientset(objects...)
+	informerFactory := informers.NewSharedInformerFactory(clientset, 0)
+
+	controller := utilflowcontrol.New(informerFactory, clientset.FlowcontrolV1(), serverConcurrencyLimit, defaultRequestWaitLimit)
+	return controller, informerFactory, nil
+}
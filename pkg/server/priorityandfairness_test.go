@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultPriorityLevelsAndFlowSchemas(t *testing.T) {
+	levels := DefaultPriorityLevels()
+	names := make([]string, len(levels))
+	for i, pl := range levels {
+		names[i] = pl.Name
+	}
+	assert.ElementsMatch(t, []string{"workload-high", "workload-low", "catch-all"}, names)
+
+	schemas := DefaultFlowSchemas()
+	schemaNames := make([]string, len(schemas))
+	for i, fs := range schemas {
+		schemaNames[i] = fs.Name
+	}
+	assert.ElementsMatch(t, []string{"system", "service-accounts", "catch-all"}, schemaNames)
+}
+
+func TestPriorityAndFairnessConfig_RegisterAppendsToDefaults(t *testing.T) {
+	cfg := &PriorityAndFairnessConfig{}
+
+	customPL := &flowcontrolv1.PriorityLevelConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}
+	cfg.RegisterPriorityLevel(customPL)
+	levels := cfg.PriorityLevels()
+	assert.Len(t, levels, len(DefaultPriorityLevels())+1)
+	assert.Equal(t, "custom", levels[len(levels)-1].Name)
+
+	customFS := &flowcontrolv1.FlowSchema{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}
+	cfg.RegisterFlowSchema(customFS)
+	schemas := cfg.FlowSchemas()
+	assert.Len(t, schemas, len(DefaultFlowSchemas())+1)
+	assert.Equal(t, "custom", schemas[len(schemas)-1].Name)
+}
@@ -0,0 +1,177 @@
+// Package server assembles a k8s.io/apiserver GenericAPIServer around the
+// REST strategies defined in pkg/strategy.
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/openapi"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	genericfilters "k8s.io/apiserver/pkg/server/filters"
+	"k8s.io/apiserver/pkg/server/options"
+	openapicommon "k8s.io/kube-openapi/pkg/common"
+)
+
+// defaultMaxRequestsInFlight and defaultMaxMutatingRequestsInFlight match the
+// defaults options.NewServerRunOptions() sets for a standalone kube-apiserver,
+// so Config behaves the same whether or not an embedder overrides them.
+const (
+	defaultMaxRequestsInFlight         = 400
+	defaultMaxMutatingRequestsInFlight = 200
+)
+
+// Config configures a Server.
+type Config struct {
+	// Name identifies this API server, used as its OpenAPI title and as the
+	// registry prefix passed to DefaultOptions.
+	Name string
+
+	// Version is reported in the server's OpenAPI info and /version endpoint.
+	Version string
+
+	Scheme       *runtime.Scheme
+	CodecFactory *serializer.CodecFactory
+
+	HTTPListenPort  int
+	HTTPSListenPort int
+
+	// OpenAPIConfig returns the OpenAPI definitions to serve. If nil, no
+	// OpenAPI config is installed.
+	OpenAPIConfig openapicommon.GetOpenAPIDefinitions
+
+	// MaxRequestsInFlight and MaxMutatingRequestsInFlight bound the legacy
+	// max-in-flight limiter. Once PriorityAndFairness is enabled the limiter
+	// is replaced, and their sum becomes APF's total server concurrency limit.
+	// Zero means use the same defaults as options.NewServerRunOptions().
+	MaxRequestsInFlight         int
+	MaxMutatingRequestsInFlight int
+
+	// LongRunningVerbs lists verbs (e.g. "watch", "proxy") exempted from the
+	// in-flight limiters because they're expected to run indefinitely.
+	LongRunningVerbs []string
+
+	// PriorityAndFairness, when Enabled, swaps the handler chain's legacy
+	// max-in-flight limiter for API Priority and Fairness.
+	PriorityAndFairness *PriorityAndFairnessConfig
+
+	// DefaultOptions holds the generic apiserver flags (auth, audit, feature
+	// gates, ...) an embedder can bind to its own CLI before calling New.
+	DefaultOptions *options.RecommendedOptions
+
+	// Config is the generic apiserver config New builds from the fields
+	// above. It's only valid to read after New returns.
+	Config genericapiserver.Config
+}
+
+// complete fills in Config defaults. It's idempotent and safe to call more
+// than once.
+func (c *Config) complete() {
+	if c.Name == "" {
+		c.Name = "mink"
+	}
+	if c.HTTPListenPort == 0 {
+		c.HTTPListenPort = 8080
+	}
+	if c.HTTPSListenPort == 0 {
+		c.HTTPSListenPort = 8081
+	}
+	if len(c.LongRunningVerbs) == 0 {
+		c.LongRunningVerbs = []string{"watch", "proxy"}
+	}
+	if c.Scheme == nil {
+		c.Scheme = runtime.NewScheme()
+	}
+	if c.CodecFactory == nil {
+		codecs := serializer.NewCodecFactory(c.Scheme)
+		c.CodecFactory = &codecs
+	}
+	if c.DefaultOptions == nil {
+		c.DefaultOptions = options.NewRecommendedOptions("/registry/"+c.Name, c.CodecFactory.LegacyCodec())
+	}
+}
+
+// Server wraps a running GenericAPIServer and the generic apiserver config it
+// was built from.
+type Server struct {
+	GenericAPIServer *genericapiserver.GenericAPIServer
+	Config           *genericapiserver.RecommendedConfig
+}
+
+// New assembles a GenericAPIServer from config. The handler chain uses API
+// Priority and Fairness in place of the legacy max-in-flight limiter whenever
+// config.PriorityAndFairness.Enabled is set — genericapiserver's own
+// DefaultBuildHandlerChain makes that swap automatically based on whether
+// genericConfig.FlowControl is non-nil, so New constructs and attaches the
+// controller and starts it, with its informers, from a post-start hook.
+func New(config *Config) (*Server, error) {
+	config.complete()
+
+	genericConfig := genericapiserver.NewConfig(*config.CodecFactory)
+
+	genericConfig.MaxRequestsInFlight = config.MaxRequestsInFlight
+	if genericConfig.MaxRequestsInFlight == 0 {
+		genericConfig.MaxRequestsInFlight = defaultMaxRequestsInFlight
+	}
+	genericConfig.MaxMutatingRequestsInFlight = config.MaxMutatingRequestsInFlight
+	if genericConfig.MaxMutatingRequestsInFlight == 0 {
+		genericConfig.MaxMutatingRequestsInFlight = defaultMaxMutatingRequestsInFlight
+	}
+	genericConfig.LongRunningFunc = genericfilters.BasicLongRunningRequestCheck(
+		sets.NewString(config.LongRunningVerbs...), sets.NewString(),
+	)
+
+	if config.OpenAPIConfig != nil {
+		genericConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(
+			config.OpenAPIConfig, openapi.NewDefinitionNamer(config.Scheme),
+		)
+		genericConfig.OpenAPIConfig.Info.Title = config.Name
+		genericConfig.OpenAPIConfig.Info.Version = config.Version
+	}
+
+	var informerFactory informerStarter
+	if config.PriorityAndFairness != nil && config.PriorityAndFairness.Enabled {
+		controller, informers, err := newFlowControlController(config.PriorityAndFairness, genericConfig.MaxRequestsInFlight+genericConfig.MaxMutatingRequestsInFlight)
+		if err != nil {
+			return nil, fmt.Errorf("server: configuring priority and fairness: %w", err)
+		}
+		genericConfig.FlowControl = controller
+		informerFactory = informers
+	}
+
+	config.Config = *genericConfig
+
+	completedConfig := genericConfig.Complete(nil)
+	genericServer, err := completedConfig.New(config.Name, genericapiserver.NewEmptyDelegate())
+	if err != nil {
+		return nil, fmt.Errorf("server: building generic API server: %w", err)
+	}
+
+	if genericConfig.FlowControl != nil {
+		flowControl := genericConfig.FlowControl
+		genericServer.AddPostStartHookOrDie("priority-and-fairness-config-consumer", func(hookCtx genericapiserver.PostStartHookContext) error {
+			if informerFactory != nil {
+				informerFactory.Start(hookCtx.StopCh)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-hookCtx.StopCh
+				cancel()
+			}()
+			go flowControl.Run(ctx)
+			return nil
+		})
+	}
+
+	return &Server{GenericAPIServer: genericServer, Config: &genericapiserver.RecommendedConfig{Config: *genericConfig}}, nil
+}
+
+// informerStarter is the subset of informers.SharedInformerFactory New needs;
+// kept narrow so flowcontrol.go's return type doesn't leak further than this
+// file requires.
+type informerStarter interface {
+	Start(stopCh <-chan struct{})
+}
@@ -1,7 +1,15 @@
 // Package strategy provides the core strategy layer defining interfaces for Kubernetes-like API resource operations.
 package strategy
 
-import "k8s.io/apimachinery/pkg/runtime"
+import (
+	"context"
+
+	"github.com/obot-platform/kinm/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+)
 
 type CompleteStrategy interface {
 	Creater
@@ -15,3 +23,40 @@ type CompleteStrategy interface {
 	Destroy()
 	Scheme() *runtime.Scheme
 }
+
+// Creater creates new objects of the strategy's kind.
+type Creater interface {
+	New() types.Object
+	Create(ctx context.Context, obj types.Object, opts *metav1.CreateOptions) (types.Object, error)
+}
+
+// Updater updates an existing object of the strategy's kind.
+type Updater interface {
+	Update(ctx context.Context, obj types.Object, opts *metav1.UpdateOptions) (types.Object, error)
+}
+
+// StatusUpdater updates only the status subresource of an existing object.
+type StatusUpdater interface {
+	UpdateStatus(ctx context.Context, obj types.Object, opts *metav1.UpdateOptions) (types.Object, error)
+}
+
+// Getter retrieves a single object of the strategy's kind by namespace and name.
+type Getter interface {
+	Get(ctx context.Context, namespace, name string) (types.Object, error)
+}
+
+// Lister retrieves a list of objects of the strategy's kind.
+type Lister interface {
+	NewList() types.ObjectList
+	List(ctx context.Context, namespace string, opts storage.ListOptions) (types.ObjectList, error)
+}
+
+// Deleter deletes a single object of the strategy's kind.
+type Deleter interface {
+	Delete(ctx context.Context, obj types.Object, opts *metav1.DeleteOptions) (types.Object, error)
+}
+
+// Watcher streams change events for objects of the strategy's kind.
+type Watcher interface {
+	Watch(ctx context.Context, namespace string, opts storage.ListOptions) (watch.Interface, error)
+}
@@ -0,0 +1,148 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	kinmotel "github.com/obot-platform/kinm/pkg/otel"
+	"github.com/obot-platform/kinm/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+// TracingStrategy wraps a CompleteStrategy and starts a span named
+// "strategy.<verb>.<resource>" around every operation, so embedders can get DB
+// and API-server spans to show up together without editing their own strategy
+// implementations.
+type TracingStrategy struct {
+	CompleteStrategy
+	Tracer trace.Tracer
+}
+
+// NewTracingStrategy returns a CompleteStrategy that traces every call to strategy.
+func NewTracingStrategy(strategy CompleteStrategy, tracer trace.Tracer) *TracingStrategy {
+	return &TracingStrategy{CompleteStrategy: strategy, Tracer: tracer}
+}
+
+func (t *TracingStrategy) Create(ctx context.Context, obj types.Object, opts *metav1.CreateOptions) (types.Object, error) {
+	ctx, span := t.start(ctx, "create", obj)
+	defer span.End()
+
+	span.SetAttributes(kinmotel.CreateOptionsToAttributes(opts)...)
+	span.SetAttributes(kinmotel.ObjectToAttributes(obj)...)
+
+	result, err := t.CompleteStrategy.Create(ctx, obj, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) Update(ctx context.Context, obj types.Object, opts *metav1.UpdateOptions) (types.Object, error) {
+	ctx, span := t.start(ctx, "update", obj)
+	defer span.End()
+
+	span.SetAttributes(kinmotel.UpdateOptionsToAttributes(opts)...)
+	span.SetAttributes(kinmotel.ObjectToAttributes(obj)...)
+
+	result, err := t.CompleteStrategy.Update(ctx, obj, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) UpdateStatus(ctx context.Context, obj types.Object, opts *metav1.UpdateOptions) (types.Object, error) {
+	ctx, span := t.start(ctx, "updateStatus", obj)
+	defer span.End()
+
+	span.SetAttributes(kinmotel.UpdateOptionsToAttributes(opts)...)
+	span.SetAttributes(kinmotel.ObjectToAttributes(obj)...)
+
+	result, err := t.CompleteStrategy.UpdateStatus(ctx, obj, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) Get(ctx context.Context, namespace, name string) (types.Object, error) {
+	ctx, span := t.start(ctx, "get", t.New())
+	defer span.End()
+
+	span.SetAttributes(attribute.String("namespace", namespace), attribute.String("name", name))
+
+	result, err := t.CompleteStrategy.Get(ctx, namespace, name)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) List(ctx context.Context, namespace string, opts storage.ListOptions) (types.ObjectList, error) {
+	ctx, span := t.start(ctx, "list", t.New())
+	defer span.End()
+
+	span.SetAttributes(kinmotel.ListOptionsToAttributes(opts)...)
+	span.SetAttributes(attribute.String("namespace", namespace))
+
+	result, err := t.CompleteStrategy.List(ctx, namespace, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) Delete(ctx context.Context, obj types.Object, opts *metav1.DeleteOptions) (types.Object, error) {
+	ctx, span := t.start(ctx, "delete", obj)
+	defer span.End()
+
+	span.SetAttributes(kinmotel.DeleteOptionsToAttributes(opts)...)
+	span.SetAttributes(kinmotel.ObjectToAttributes(obj)...)
+
+	result, err := t.CompleteStrategy.Delete(ctx, obj, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+func (t *TracingStrategy) Watch(ctx context.Context, namespace string, opts storage.ListOptions) (watch.Interface, error) {
+	ctx, span := t.start(ctx, "watch", t.New())
+	defer span.End()
+
+	span.SetAttributes(kinmotel.WatchAttributes(opts)...)
+	span.SetAttributes(attribute.String("namespace", namespace))
+
+	result, err := t.CompleteStrategy.Watch(ctx, namespace, opts)
+	recordErr(span, err)
+	return result, err
+}
+
+// start begins a span named "strategy.<verb>.<resource>", tagging it with the
+// GroupVersionResource of obj as resolved from the strategy's scheme.
+func (t *TracingStrategy) start(ctx context.Context, verb string, obj runtime.Object) (context.Context, trace.Span) {
+	gvr, _ := gvrFor(t.Scheme(), obj)
+
+	ctx, span := t.Tracer.Start(ctx, fmt.Sprintf("strategy.%s.%s", verb, gvr.Resource))
+	span.SetAttributes(
+		attribute.String("k8s.group", gvr.Group),
+		attribute.String("k8s.version", gvr.Version),
+		attribute.String("k8s.resource", gvr.Resource),
+	)
+	return ctx, span
+}
+
+func recordErr(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// gvrFor resolves obj's GroupVersionKind using scheme and derives the resource
+// from it by a best-effort guess (plural, lower-cased Kind), since the strategy
+// layer has no RESTMapper of its own.
+func gvrFor(scheme *runtime.Scheme, obj runtime.Object) (schema.GroupVersionResource, error) {
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return schema.GroupVersionResource{}, err
+	}
+	return meta.UnsafeGuessKindToResource(gvks[0]), nil
+}
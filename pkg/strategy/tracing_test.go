@@ -0,0 +1,183 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/obot-platform/kinm/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+// fakeStrategy is a minimal CompleteStrategy whose return values and errors
+// are configured per test, so TracingStrategy can be exercised without a real
+// storage backend.
+type fakeStrategy struct {
+	scheme *runtime.Scheme
+
+	obj     types.Object
+	objList types.ObjectList
+	watcher watch.Interface
+	err     error
+}
+
+func newFakeObj() types.Object {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "test.kinm.io", Version: "v1", Kind: "Widget"})
+	u.SetName("my-widget")
+	u.SetNamespace("my-namespace")
+	return u
+}
+
+func newFakeObjList() types.ObjectList {
+	l := &unstructured.UnstructuredList{}
+	l.SetGroupVersionKind(schema.GroupVersionKind{Group: "test.kinm.io", Version: "v1", Kind: "WidgetList"})
+	return l
+}
+
+func (f *fakeStrategy) New() types.Object         { return newFakeObj() }
+func (f *fakeStrategy) NewList() types.ObjectList { return newFakeObjList() }
+
+func (f *fakeStrategy) Create(context.Context, types.Object, *metav1.CreateOptions) (types.Object, error) {
+	return f.obj, f.err
+}
+
+func (f *fakeStrategy) Update(context.Context, types.Object, *metav1.UpdateOptions) (types.Object, error) {
+	return f.obj, f.err
+}
+
+func (f *fakeStrategy) UpdateStatus(context.Context, types.Object, *metav1.UpdateOptions) (types.Object, error) {
+	return f.obj, f.err
+}
+
+func (f *fakeStrategy) Get(context.Context, string, string) (types.Object, error) {
+	return f.obj, f.err
+}
+
+func (f *fakeStrategy) List(context.Context, string, storage.ListOptions) (types.ObjectList, error) {
+	return f.objList, f.err
+}
+
+func (f *fakeStrategy) Delete(context.Context, types.Object, *metav1.DeleteOptions) (types.Object, error) {
+	return f.obj, f.err
+}
+
+func (f *fakeStrategy) Watch(context.Context, string, storage.ListOptions) (watch.Interface, error) {
+	return f.watcher, f.err
+}
+
+func (f *fakeStrategy) Destroy() {}
+
+func (f *fakeStrategy) Scheme() *runtime.Scheme { return f.scheme }
+
+func newTracingTestStrategy(err error) (*TracingStrategy, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	base := &fakeStrategy{
+		scheme:  runtime.NewScheme(),
+		obj:     newFakeObj(),
+		objList: newFakeObjList(),
+		watcher: watch.NewFake(),
+		err:     err,
+	}
+
+	return NewTracingStrategy(base, tp.Tracer("test")), sr
+}
+
+func attrMap(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(span.Attributes()))
+	for _, kv := range span.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestTracingStrategy_Get(t *testing.T) {
+	strat, sr := newTracingTestStrategy(nil)
+
+	_, err := strat.Get(context.Background(), "my-namespace", "my-widget")
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "strategy.get.widgets", spans[0].Name())
+
+	attrs := attrMap(spans[0])
+	assert.Equal(t, "my-namespace", attrs["namespace"].AsString())
+	assert.Equal(t, "my-widget", attrs["name"].AsString())
+	assert.Equal(t, "test.kinm.io", attrs["k8s.group"].AsString())
+}
+
+func TestTracingStrategy_List(t *testing.T) {
+	strat, sr := newTracingTestStrategy(nil)
+
+	_, err := strat.List(context.Background(), "my-namespace", storage.ListOptions{ResourceVersion: "42"})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "strategy.list.widgets", spans[0].Name())
+
+	attrs := attrMap(spans[0])
+	assert.Equal(t, "42", attrs["resourceVersion"].AsString())
+	assert.Equal(t, "my-namespace", attrs["namespace"].AsString())
+}
+
+func TestTracingStrategy_Watch(t *testing.T) {
+	strat, sr := newTracingTestStrategy(nil)
+
+	sendInitialEvents := true
+	_, err := strat.Watch(context.Background(), "my-namespace", storage.ListOptions{SendInitialEvents: &sendInitialEvents})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "strategy.watch.widgets", spans[0].Name())
+
+	attrs := attrMap(spans[0])
+	assert.Equal(t, true, attrs["watch"].AsBool())
+	assert.Equal(t, true, attrs["sendInitialEvents"].AsBool())
+}
+
+func TestTracingStrategy_Delete_RecordsError(t *testing.T) {
+	testErr := errors.New("widget is locked")
+	strat, sr := newTracingTestStrategy(testErr)
+
+	_, err := strat.Delete(context.Background(), newFakeObj(), &metav1.DeleteOptions{})
+	require.ErrorIs(t, err, testErr)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "strategy.delete.widgets", spans[0].Name())
+
+	require.Len(t, spans[0].Events(), 1, "RecordError should add an exception event")
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+
+	status := spans[0].Status()
+	assert.Equal(t, codes.Error, status.Code)
+	assert.Equal(t, testErr.Error(), status.Description)
+}
+
+func TestTracingStrategy_Create_NoErrorRecordsNoEvent(t *testing.T) {
+	strat, sr := newTracingTestStrategy(nil)
+
+	_, err := strat.Create(context.Background(), newFakeObj(), &metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Events())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}